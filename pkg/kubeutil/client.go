@@ -0,0 +1,66 @@
+package kubeutil
+
+import (
+	"context"
+	"time"
+)
+
+// ClusterRoleBindingSpec describes a ClusterRoleBinding to reconcile.
+type ClusterRoleBindingSpec struct {
+	Name               string
+	ClusterRole        string
+	Namespace          string
+	ServiceAccountName string
+	// Labels are set on the ClusterRoleBinding itself, e.g. so a cleanup pass
+	// can find cluster-scoped resources tagged with a particular owner.
+	Labels map[string]string
+}
+
+// BoundObjectReference ties a requested token to the lifetime of another
+// object (e.g. a Pod), mirroring authenticationv1.BoundObjectReference.
+type BoundObjectReference struct {
+	Kind       string
+	APIVersion string
+	Name       string
+	UID        string
+}
+
+// TokenRequestOptions configures a ServiceAccount TokenRequest.
+type TokenRequestOptions struct {
+	// Audiences restricts the token to the given audiences; empty means the
+	// apiserver's default audience.
+	Audiences []string
+	// ExpirationSeconds requests a token lifetime; nil means the apiserver default.
+	ExpirationSeconds *int64
+	// BoundObjectRef ties the token to another object's lifetime, e.g. a Pod
+	// scraping metrics, so it is invalidated when that object is deleted.
+	BoundObjectRef *BoundObjectReference
+}
+
+// TokenResult is the result of a successful TokenRequest.
+type TokenResult struct {
+	Token string
+	// ExpirationTimestamp is when the token expires, so callers can
+	// proactively refresh before relying on 401s.
+	ExpirationTimestamp time.Time
+}
+
+// Client is the interface e2e and controller code use to talk to the
+// cluster, independent of whether calls are served by client-go or by
+// shelling out to kubectl. See RestClient and CmdClient for the two
+// implementations.
+type Client interface {
+	// ApplyClusterRoleBinding idempotently applies a ClusterRoleBinding.
+	ApplyClusterRoleBinding(ctx context.Context, spec ClusterRoleBindingSpec) error
+
+	// RequestServiceAccountToken requests a token for the given ServiceAccount.
+	// CmdClient retries until ctx is done; RestClient makes a single request
+	// and returns its error.
+	RequestServiceAccountToken(ctx context.Context, ns, sa string, opts TokenRequestOptions) (TokenResult, error)
+
+	// LabelNamespace sets (overwriting) the given labels on a namespace.
+	LabelNamespace(ctx context.Context, ns string, kv map[string]string) error
+
+	// WaitForServiceEndpoints blocks until the named Service has at least one ready endpoint.
+	WaitForServiceEndpoints(ctx context.Context, ns, name string) error
+}
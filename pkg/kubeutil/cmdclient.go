@@ -0,0 +1,64 @@
+package kubeutil
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// CmdClient implements Client by shelling out to kubectl through a CmdRunner.
+// It exists so callers that cannot depend on client-go (or clusters without a
+// reachable API server from this process) keep working; RestClient is the
+// preferred backend going forward.
+type CmdClient struct {
+	runner CmdRunner
+	logger slo.Logger
+}
+
+// NewCmdClient wraps the given CmdRunner as a Client. runner may be nil, in
+// which case DefaultRunner is used.
+func NewCmdClient(runner CmdRunner, logger slo.Logger) *CmdClient {
+	if runner == nil {
+		runner = DefaultRunner{}
+	}
+	return &CmdClient{runner: runner, logger: slo.NewLogger(logger)}
+}
+
+func (c *CmdClient) ApplyClusterRoleBinding(ctx context.Context, spec ClusterRoleBindingSpec) error {
+	return ApplyClusterRoleBinding(ctx, c.logger, c.runner, spec.Name, spec.ClusterRole, spec.Namespace, spec.ServiceAccountName, spec.Labels)
+}
+
+func (c *CmdClient) RequestServiceAccountToken(ctx context.Context, ns, sa string, opts TokenRequestOptions) (TokenResult, error) {
+	return ServiceAccountToken(ctx, c.logger, c.runner, ns, sa, opts)
+}
+
+func (c *CmdClient) LabelNamespace(ctx context.Context, ns string, kv map[string]string) error {
+	args := []string{"label", "--overwrite", "ns", ns}
+	for k, v := range kv {
+		args = append(args, fmt.Sprintf("%s=%s", k, v))
+	}
+	cmd := exec.Command("kubectl", args...)
+	_, err := c.runner.Run(ctx, c.logger, cmd)
+	if err != nil {
+		return fmt.Errorf("label namespace %q failed: %w", ns, err)
+	}
+	return nil
+}
+
+func (c *CmdClient) WaitForServiceEndpoints(ctx context.Context, ns, name string) error {
+	return Retry(ctx, endpointsRetryOpts, func(ctx context.Context) error {
+		cmd := exec.Command("kubectl", "get", "endpoints", name,
+			"-n", ns,
+			"-o", "jsonpath={.subsets[*].addresses[*].ip}")
+		result, err := c.runner.Run(ctx, c.logger, cmd)
+		if err != nil {
+			return fmt.Errorf("waiting for endpoints %s/%s: %w", ns, name, err)
+		}
+		if result.Stdout == "" {
+			return fmt.Errorf("endpoints %s/%s has no ready addresses yet", ns, name)
+		}
+		return nil
+	})
+}
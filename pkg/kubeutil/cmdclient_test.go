@@ -0,0 +1,54 @@
+package kubeutil
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// fakeRunner records every command it's asked to run and returns canned
+// RunResults keyed by the joined command line, so CmdClient tests don't
+// shell out to a real kubectl.
+type fakeRunner struct {
+	calls     [][]string
+	responses map[string]RunResult
+	err       map[string]error
+}
+
+func (f *fakeRunner) Run(_ context.Context, _ slo.Logger, cmd *exec.Cmd) (RunResult, error) {
+	f.calls = append(f.calls, cmd.Args)
+	key := strings.Join(cmd.Args, " ")
+	return f.responses[key], f.err[key]
+}
+
+func TestCmdClientLabelNamespace(t *testing.T) {
+	r := &fakeRunner{}
+	c := NewCmdClient(r, nil)
+
+	if err := c.LabelNamespace(context.Background(), "my-operator-system", map[string]string{"k": "v"}); err != nil {
+		t.Fatalf("LabelNamespace: %v", err)
+	}
+	if len(r.calls) != 1 {
+		t.Fatalf("calls = %v, want exactly one kubectl invocation", r.calls)
+	}
+	got := strings.Join(r.calls[0], " ")
+	if !strings.Contains(got, "label --overwrite ns my-operator-system") || !strings.Contains(got, "k=v") {
+		t.Errorf("command = %q, want a kubectl label --overwrite invocation with k=v", got)
+	}
+}
+
+func TestCmdClientWaitForServiceEndpoints(t *testing.T) {
+	r := &fakeRunner{
+		responses: map[string]RunResult{
+			"kubectl get endpoints metrics -n my-operator-system -o jsonpath={.subsets[*].addresses[*].ip}": {Stdout: "10.0.0.1"},
+		},
+	}
+	c := NewCmdClient(r, nil)
+
+	if err := c.WaitForServiceEndpoints(context.Background(), "my-operator-system", "metrics"); err != nil {
+		t.Fatalf("WaitForServiceEndpoints: %v", err)
+	}
+}
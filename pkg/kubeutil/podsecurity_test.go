@@ -0,0 +1,32 @@
+package kubeutil
+
+import "testing"
+
+func TestPodSecuritySpecLabels(t *testing.T) {
+	spec := PodSecuritySpec{
+		Enforce: PodSecurityModeSpec{Level: PodSecurityBaseline},
+		Warn:    PodSecurityModeSpec{Level: PodSecurityRestricted, Version: "v1.29"},
+	}
+
+	got := spec.labels()
+	want := map[string]string{
+		"pod-security.kubernetes.io/enforce":      "baseline",
+		"pod-security.kubernetes.io/warn":         "restricted",
+		"pod-security.kubernetes.io/warn-version": "v1.29",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("labels = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("labels[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestPodSecuritySpecLabelsEmpty(t *testing.T) {
+	if got := (PodSecuritySpec{}).labels(); len(got) != 0 {
+		t.Errorf("labels = %v, want empty for an all-zero spec", got)
+	}
+}
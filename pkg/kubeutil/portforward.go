@@ -0,0 +1,253 @@
+package kubeutil
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// PortForwardScrapeConfig configures PortForwardScrape. The curl-pod path
+// (spin up a pod, curl from inside the cluster) remains available as a
+// fallback scrape runner for environments where port-forward is blocked
+// (e.g. some managed clusters' admission policies).
+type PortForwardScrapeConfig struct {
+	RestConfig *rest.Config
+	Clientset  kubernetes.Interface
+
+	Namespace   string
+	ServiceName string
+	// Port is the pod port backing the Service's metrics endpoint (the
+	// container port, not the Service port), e.g. 8443.
+	Port int
+
+	ServiceAccountName string
+	TokenOptions       TokenRequestOptions
+
+	// Insecure skips TLS verification, which kube-rbac-proxy's self-signed
+	// serving cert requires unless the test trusts the cluster's CA.
+	Insecure bool
+
+	RetryOpts RetryOptions
+	Logger    slo.Logger
+}
+
+// ScrapeResult is the outcome of a PortForwardScrape call: the raw response
+// body plus the Prometheus text-format families parsed from it.
+type ScrapeResult struct {
+	Body     string
+	Families map[string]*dto.MetricFamily
+}
+
+// Sample returns the value of the first sample of the named metric whose
+// labels are a superset of want, so callers can assert on individual metric
+// samples (e.g. Sample("controller_runtime_reconcile_total",
+// map[string]string{"controller": "x"})) instead of strings.Contains.
+func (r ScrapeResult) Sample(name string, want map[string]string) (float64, bool) {
+	family, ok := r.Families[name]
+	if !ok {
+		return 0, false
+	}
+
+	for _, m := range family.GetMetric() {
+		got := map[string]string{}
+		for _, lp := range m.GetLabel() {
+			got[lp.GetName()] = lp.GetValue()
+		}
+		if !labelsMatch(got, want) {
+			continue
+		}
+		switch {
+		case m.Counter != nil:
+			return m.GetCounter().GetValue(), true
+		case m.Gauge != nil:
+			return m.GetGauge().GetValue(), true
+		case m.Untyped != nil:
+			return m.GetUntyped().GetValue(), true
+		}
+	}
+	return 0, false
+}
+
+func labelsMatch(got, want map[string]string) bool {
+	for k, v := range want {
+		if got[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// PortForwardScrape opens an in-process spdy port-forward to a pod backing
+// ServiceName, requests a scrape token, and GETs /metrics through a
+// RetryHTTPClient, replacing the round trip of spinning up a curl pod,
+// waiting for it, and tailing its logs.
+func PortForwardScrape(ctx context.Context, cfg PortForwardScrapeConfig) (ScrapeResult, error) {
+	logger := slo.NewLogger(cfg.Logger)
+
+	pod, err := findServicePod(ctx, cfg.Clientset, cfg.Namespace, cfg.ServiceName)
+	if err != nil {
+		return ScrapeResult{}, err
+	}
+
+	fw, err := newPodPortForwarder(cfg.RestConfig, cfg.Clientset, pod, cfg.Port)
+	if err != nil {
+		return ScrapeResult{}, err
+	}
+	defer fw.Close()
+
+	localPort, err := fw.Ready(ctx)
+	if err != nil {
+		return ScrapeResult{}, fmt.Errorf("port-forward to pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	tokenOpts := cfg.TokenOptions
+	if tokenOpts.BoundObjectRef == nil {
+		// Bind the scrape token to the pod we're actually forwarding to, so it's
+		// invalidated the moment that pod goes away instead of outliving it.
+		tokenOpts.BoundObjectRef = &BoundObjectReference{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Name:       pod.Name,
+			UID:        string(pod.UID),
+		}
+	}
+
+	restClient := NewRestClient(cfg.Clientset, logger)
+	tokenResult, err := restClient.RequestServiceAccountToken(ctx, cfg.Namespace, cfg.ServiceAccountName, tokenOpts)
+	if err != nil {
+		return ScrapeResult{}, fmt.Errorf("request scrape token: %w", err)
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.Insecure}, //nolint:gosec // metrics endpoint uses a self-signed serving cert
+		},
+	}
+	retryClient := NewRetryHTTPClient(httpClient, cfg.RetryOpts)
+
+	url := fmt.Sprintf("https://127.0.0.1:%d/metrics", localPort)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return ScrapeResult{}, fmt.Errorf("build scrape request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+tokenResult.Token)
+
+	resp, err := retryClient.Do(ctx, req)
+	if err != nil {
+		return ScrapeResult{}, fmt.Errorf("scrape %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ScrapeResult{}, fmt.Errorf("read metrics body: %w", err)
+	}
+
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return ScrapeResult{}, fmt.Errorf("parse metrics text: %w", err)
+	}
+
+	return ScrapeResult{Body: string(body), Families: families}, nil
+}
+
+func findServicePod(ctx context.Context, clientset kubernetes.Interface, ns, serviceName string) (*corev1.Pod, error) {
+	svc, err := clientset.CoreV1().Services(ns).Get(ctx, serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get service %s/%s: %w", ns, serviceName, err)
+	}
+
+	pods, err := clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list pods for service %s/%s: %w", ns, serviceName, err)
+	}
+
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running pod backs service %s/%s", ns, serviceName)
+}
+
+// podPortForwarder wraps a client-go portforward.PortForwarder so callers get
+// a Ready(ctx) that blocks until the tunnel is up and returns the ephemeral
+// local port, instead of juggling ready/error/stop channels directly.
+type podPortForwarder struct {
+	forwarder *portforward.PortForwarder
+	readyCh   chan struct{}
+	errCh     chan error
+	stopCh    chan struct{}
+}
+
+func newPodPortForwarder(cfg *rest.Config, clientset kubernetes.Interface, pod *corev1.Pod, podPort int) (*podPortForwarder, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build spdy round tripper: %w", err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", podPort)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("create port forwarder: %w", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	return &podPortForwarder{forwarder: fw, readyCh: readyCh, errCh: errCh, stopCh: stopCh}, nil
+}
+
+// Ready blocks until the tunnel is established and returns the local port it
+// was assigned.
+func (p *podPortForwarder) Ready(ctx context.Context) (int, error) {
+	select {
+	case <-p.readyCh:
+	case err := <-p.errCh:
+		return 0, err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	ports, err := p.forwarder.GetPorts()
+	if err != nil {
+		return 0, fmt.Errorf("get forwarded ports: %w", err)
+	}
+	if len(ports) == 0 {
+		return 0, fmt.Errorf("no forwarded ports")
+	}
+	return int(ports[0].Local), nil
+}
+
+// Close tears down the tunnel.
+func (p *podPortForwarder) Close() {
+	close(p.stopCh)
+}
@@ -0,0 +1,95 @@
+package kubeutil
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func counterMetric(value float64, labels map[string]string) *dto.Metric {
+	m := &dto.Metric{Counter: &dto.Counter{Value: &value}}
+	for k, v := range labels {
+		k, v := k, v
+		m.Label = append(m.Label, &dto.LabelPair{Name: &k, Value: &v})
+	}
+	return m
+}
+
+func TestScrapeResultSample(t *testing.T) {
+	name := "controller_runtime_reconcile_total"
+	result := ScrapeResult{
+		Families: map[string]*dto.MetricFamily{
+			name: {Metric: []*dto.Metric{
+				counterMetric(3, map[string]string{"controller": "my-operator"}),
+				counterMetric(7, map[string]string{"controller": "other"}),
+			}},
+		},
+	}
+
+	value, ok := result.Sample(name, map[string]string{"controller": "other"})
+	if !ok || value != 7 {
+		t.Fatalf("Sample(controller=other) = (%v, %v), want (7, true)", value, ok)
+	}
+
+	if _, ok := result.Sample("missing_metric", nil); ok {
+		t.Error("Sample(missing_metric) = ok, want not found")
+	}
+}
+
+func TestLabelsMatch(t *testing.T) {
+	got := map[string]string{"controller": "my-operator", "namespace": "my-operator-system"}
+
+	if !labelsMatch(got, map[string]string{"controller": "my-operator"}) {
+		t.Error("labelsMatch should succeed when want is a subset of got")
+	}
+	if labelsMatch(got, map[string]string{"controller": "other"}) {
+		t.Error("labelsMatch should fail on a mismatched value")
+	}
+	if !labelsMatch(got, nil) {
+		t.Error("labelsMatch should succeed when want is empty")
+	}
+}
+
+func TestFindServicePod(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "my-operator-system"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "my-operator"}},
+	}
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-operator-0", Namespace: "my-operator-system", Labels: map[string]string{"app": "my-operator"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-operator-1", Namespace: "my-operator-system", Labels: map[string]string{"app": "my-operator"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	clientset := fake.NewSimpleClientset(svc, pending, running)
+
+	pod, err := findServicePod(context.Background(), clientset, "my-operator-system", "metrics")
+	if err != nil {
+		t.Fatalf("findServicePod: %v", err)
+	}
+	if pod.Name != "my-operator-1" {
+		t.Errorf("pod = %q, want the running pod %q", pod.Name, "my-operator-1")
+	}
+}
+
+func TestFindServicePodNoneRunning(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "my-operator-system"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "my-operator"}},
+	}
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-operator-0", Namespace: "my-operator-system", Labels: map[string]string{"app": "my-operator"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	clientset := fake.NewSimpleClientset(svc, pending)
+
+	if _, err := findServicePod(context.Background(), clientset, "my-operator-system", "metrics"); err == nil {
+		t.Fatal("expected an error when no pod backing the service is Running")
+	}
+}
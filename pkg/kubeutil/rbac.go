@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/yeongki/my-operator/pkg/slo"
@@ -12,34 +13,24 @@ import (
 // ApplyClusterRoleBinding applies a ClusterRoleBinding in an idempotent way (kubectl apply).
 // - logger may be nil (no-op).
 // - r may be nil (uses DefaultRunner).
-func ApplyClusterRoleBinding(ctx context.Context, logger slo.Logger, r CmdRunner, name, clusterRole, ns, sa string) error {
+// - labels may be nil; when set, they're applied to the ClusterRoleBinding
+//   itself so a later cleanup pass can find it by selector.
+func ApplyClusterRoleBinding(ctx context.Context, logger slo.Logger, r CmdRunner, name, clusterRole, ns, sa string, labels map[string]string) error {
 	logger = slo.NewLogger(logger)
 	if r == nil {
 		r = DefaultRunner{}
 	}
 
-	logger.Logf("apply ClusterRoleBinding name=%q role=%q sa=%s/%s", name, clusterRole, ns, sa)
-
-	manifest := fmt.Sprintf(`apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRoleBinding
-metadata:
-  name: %s
-roleRef:
-  apiGroup: rbac.authorization.k8s.io
-  kind: ClusterRole
-  name: %s
-subjects:
-- kind: ServiceAccount
-  name: %s
-  namespace: %s
-`, name, clusterRole, sa, ns)
+	logger.Logf("apply ClusterRoleBinding name=%q role=%q sa=%s/%s labels=%v", name, clusterRole, ns, sa, labels)
+
+	manifest := clusterRoleBindingManifest(name, clusterRole, ns, sa, labels)
 
 	cmd := exec.Command("kubectl", "apply", "-f", "-")
 	cmd.Stdin = strings.NewReader(manifest)
 
-	stdout, err := r.Run(ctx, logger, cmd)
+	result, err := r.Run(ctx, logger, cmd)
 
-	if s := strings.TrimSpace(stdout); s != "" {
+	if s := strings.TrimSpace(result.Stdout); s != "" {
 		logger.Logf("%s", strings.TrimRight(s, "\n"))
 	}
 	if err != nil {
@@ -47,3 +38,25 @@ subjects:
 	}
 	return nil
 }
+
+// clusterRoleBindingManifest renders the ClusterRoleBinding YAML applied by
+// ApplyClusterRoleBinding, including an optional metadata.labels block.
+func clusterRoleBindingManifest(name, clusterRole, ns, sa string, labels map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRoleBinding\nmetadata:\n  name: %s\n", name)
+
+	if len(labels) > 0 {
+		b.WriteString("  labels:\n")
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "    %s: %q\n", k, labels[k])
+		}
+	}
+
+	fmt.Fprintf(&b, "roleRef:\n  apiGroup: rbac.authorization.k8s.io\n  kind: ClusterRole\n  name: %s\nsubjects:\n- kind: ServiceAccount\n  name: %s\n  namespace: %s\n", clusterRole, sa, ns)
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package kubeutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	rbacv1ac "k8s.io/client-go/applyconfigurations/rbac/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// fieldManager identifies server-side-apply requests made by this package.
+const fieldManager = "my-operator-kubeutil"
+
+// endpointsRetryOpts mirrors the old hand-rolled 2s poll for WaitForServiceEndpoints.
+var endpointsRetryOpts = RetryOptions{
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     2 * time.Second,
+	Jitter:          0.2,
+}
+
+// RestClient implements Client directly on top of k8s.io/client-go, without
+// shelling out to kubectl.
+type RestClient struct {
+	clientset kubernetes.Interface
+	logger    slo.Logger
+}
+
+// NewRestClient builds a RestClient from a kubernetes.Interface, which may be
+// a real clientset built from a *rest.Config or a fake from client-go/testing.
+func NewRestClient(clientset kubernetes.Interface, logger slo.Logger) *RestClient {
+	return &RestClient{clientset: clientset, logger: slo.NewLogger(logger)}
+}
+
+// NewRestClientFromConfig builds a RestClient from a *rest.Config.
+func NewRestClientFromConfig(cfg *rest.Config, logger slo.Logger) (*RestClient, error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("building clientset: %w", err)
+	}
+	return NewRestClient(clientset, logger), nil
+}
+
+// RestConfigFromEnv loads a *rest.Config the same way kubectl would:
+// in-cluster when running as a pod, otherwise from the current kubeconfig context.
+func RestConfigFromEnv() (*rest.Config, error) {
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		return cfg, nil
+	}
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+func (c *RestClient) ApplyClusterRoleBinding(ctx context.Context, spec ClusterRoleBindingSpec) error {
+	c.logger.Logf("apply ClusterRoleBinding name=%q role=%q sa=%s/%s", spec.Name, spec.ClusterRole, spec.Namespace, spec.ServiceAccountName)
+
+	crb := rbacv1ac.ClusterRoleBinding(spec.Name).
+		WithLabels(spec.Labels).
+		WithRoleRef(rbacv1ac.RoleRef().
+			WithAPIGroup("rbac.authorization.k8s.io").
+			WithKind("ClusterRole").
+			WithName(spec.ClusterRole)).
+		WithSubjects(rbacv1ac.Subject().
+			WithKind("ServiceAccount").
+			WithName(spec.ServiceAccountName).
+			WithNamespace(spec.Namespace))
+
+	_, err := c.clientset.RbacV1().ClusterRoleBindings().Apply(ctx, crb, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("apply clusterrolebinding %q failed: %w", spec.Name, err)
+	}
+	return nil
+}
+
+func (c *RestClient) RequestServiceAccountToken(ctx context.Context, ns, sa string, opts TokenRequestOptions) (TokenResult, error) {
+	tr := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         opts.Audiences,
+			ExpirationSeconds: opts.ExpirationSeconds,
+			BoundObjectRef:    toBoundObjectRef(opts.BoundObjectRef),
+		},
+	}
+
+	out, err := c.clientset.CoreV1().ServiceAccounts(ns).CreateToken(ctx, sa, tr, metav1.CreateOptions{})
+	if err != nil {
+		return TokenResult{}, fmt.Errorf("token request failed (ns=%s sa=%s): %w", ns, sa, err)
+	}
+	if out.Status.Token == "" {
+		return TokenResult{}, fmt.Errorf("token is empty (ns=%s sa=%s)", ns, sa)
+	}
+	return TokenResult{
+		Token:               out.Status.Token,
+		ExpirationTimestamp: out.Status.ExpirationTimestamp.Time,
+	}, nil
+}
+
+func toBoundObjectRef(ref *BoundObjectReference) *authenticationv1.BoundObjectReference {
+	if ref == nil {
+		return nil
+	}
+	return &authenticationv1.BoundObjectReference{
+		Kind:       ref.Kind,
+		APIVersion: ref.APIVersion,
+		Name:       ref.Name,
+		UID:        types.UID(ref.UID),
+	}
+}
+
+func (c *RestClient) LabelNamespace(ctx context.Context, ns string, kv map[string]string) error {
+	merge := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": kv,
+		},
+	}
+	patchBytes, err := json.Marshal(merge)
+	if err != nil {
+		return fmt.Errorf("marshal namespace label patch: %w", err)
+	}
+
+	_, err = c.clientset.CoreV1().Namespaces().Patch(ctx, ns, types.MergePatchType, patchBytes, metav1.PatchOptions{
+		FieldManager: fieldManager,
+	})
+	if err != nil {
+		return fmt.Errorf("label namespace %q failed: %w", ns, err)
+	}
+	return nil
+}
+
+func (c *RestClient) WaitForServiceEndpoints(ctx context.Context, ns, name string) error {
+	return Retry(ctx, endpointsRetryOpts, func(ctx context.Context) error {
+		eps, err := c.clientset.CoreV1().Endpoints(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				c.logger.Logf("get endpoints %s/%s: %v", ns, name, err)
+			}
+			return fmt.Errorf("endpoints %s/%s not found yet: %w", ns, name, err)
+		}
+		if !hasReadyAddresses(eps) {
+			return fmt.Errorf("endpoints %s/%s has no ready addresses yet", ns, name)
+		}
+		return nil
+	})
+}
+
+func hasReadyAddresses(eps *corev1.Endpoints) bool {
+	for _, subset := range eps.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package kubeutil
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRestClientApplyClusterRoleBinding(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	c := NewRestClient(clientset, nil)
+
+	spec := ClusterRoleBindingSpec{
+		Name:               "metrics-reader",
+		ClusterRole:        "my-operator-metrics-reader",
+		Namespace:          "my-operator-system",
+		ServiceAccountName: "my-operator-controller-manager",
+		Labels:             map[string]string{"my-operator.io/e2e-profile": "abc123"},
+	}
+	if err := c.ApplyClusterRoleBinding(context.Background(), spec); err != nil {
+		t.Fatalf("ApplyClusterRoleBinding: %v", err)
+	}
+
+	crb, err := clientset.RbacV1().ClusterRoleBindings().Get(context.Background(), spec.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get clusterrolebinding: %v", err)
+	}
+	if crb.Labels["my-operator.io/e2e-profile"] != "abc123" {
+		t.Errorf("labels = %v, want e2e-profile=abc123", crb.Labels)
+	}
+	if len(crb.Subjects) != 1 || crb.Subjects[0].Name != spec.ServiceAccountName {
+		t.Errorf("subjects = %v, want a single subject named %q", crb.Subjects, spec.ServiceAccountName)
+	}
+}
+
+func TestRestClientLabelNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-operator-system"},
+	})
+	c := NewRestClient(clientset, nil)
+
+	err := c.LabelNamespace(context.Background(), "my-operator-system", map[string]string{
+		"pod-security.kubernetes.io/enforce": "baseline",
+	})
+	if err != nil {
+		t.Fatalf("LabelNamespace: %v", err)
+	}
+
+	ns, err := clientset.CoreV1().Namespaces().Get(context.Background(), "my-operator-system", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get namespace: %v", err)
+	}
+	if ns.Labels["pod-security.kubernetes.io/enforce"] != "baseline" {
+		t.Errorf("labels = %v, want pod-security.kubernetes.io/enforce=baseline", ns.Labels)
+	}
+}
+
+func TestRestClientWaitForServiceEndpoints(t *testing.T) {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "my-operator-system"},
+		Subsets: []corev1.EndpointSubset{
+			{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}},
+		},
+	}
+	clientset := fake.NewSimpleClientset(endpoints)
+	c := NewRestClient(clientset, nil)
+
+	if err := c.WaitForServiceEndpoints(context.Background(), "my-operator-system", "metrics"); err != nil {
+		t.Fatalf("WaitForServiceEndpoints: %v", err)
+	}
+}
+
+func TestRestClientWaitForServiceEndpointsNoAddresses(t *testing.T) {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "my-operator-system"},
+	}
+	clientset := fake.NewSimpleClientset(endpoints)
+	c := NewRestClient(clientset, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	if err := c.WaitForServiceEndpoints(ctx, "my-operator-system", "metrics"); err == nil {
+		t.Fatal("expected an error when no addresses are ready and ctx is already done")
+	}
+}
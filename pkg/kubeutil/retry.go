@@ -0,0 +1,87 @@
+package kubeutil
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryOptions configures Retry's backoff schedule.
+type RetryOptions struct {
+	// Attempts bounds how many times fn is called; zero means unlimited
+	// (retry until ctx is done).
+	Attempts int
+	// InitialInterval is the delay before the second attempt.
+	InitialInterval time.Duration
+	// MaxInterval caps the computed delay.
+	MaxInterval time.Duration
+	// Multiplier scales the interval after each attempt. Defaults to 2.0.
+	Multiplier float64
+	// Jitter is a fraction in [0,1] of randomness applied to each interval.
+	Jitter float64
+	// RetryIf decides whether an error is retryable. Defaults to "always retry".
+	RetryIf func(error) bool
+}
+
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.Multiplier <= 0 {
+		o.Multiplier = 2.0
+	}
+	if o.RetryIf == nil {
+		o.RetryIf = func(error) bool { return true }
+	}
+	return o
+}
+
+// Retry calls fn until it succeeds, ctx is done, Attempts is exhausted, or
+// RetryIf rejects an error as terminal. Delays follow
+// min(MaxInterval, InitialInterval*Multiplier^attempt), jittered by +/-Jitter.
+func Retry(ctx context.Context, opts RetryOptions, fn func(ctx context.Context) error) error {
+	opts = opts.withDefaults()
+
+	var lastErr error
+	for attempt := 0; opts.Attempts == 0 || attempt < opts.Attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			if lastErr == nil {
+				return err
+			}
+			return errors.Join(lastErr, err)
+		}
+
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if errors.Is(err, context.Canceled) || !opts.RetryIf(err) {
+			return err
+		}
+		if opts.Attempts != 0 && attempt+1 >= opts.Attempts {
+			return lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(lastErr, ctx.Err())
+		case <-time.After(backoffDelay(opts, attempt)):
+		}
+	}
+	return lastErr
+}
+
+func backoffDelay(opts RetryOptions, attempt int) time.Duration {
+	interval := float64(opts.InitialInterval) * math.Pow(opts.Multiplier, float64(attempt))
+	if max := float64(opts.MaxInterval); max > 0 && interval > max {
+		interval = max
+	}
+	if opts.Jitter > 0 {
+		interval *= 1 - opts.Jitter + rand.Float64()*2*opts.Jitter
+	}
+	if interval < 0 {
+		interval = 0
+	}
+	return time.Duration(interval)
+}
@@ -0,0 +1,66 @@
+package kubeutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RetryHTTPClient retries transient HTTP failures (5xx, 429, connection
+// reset/timeout) on top of Retry, replacing bespoke polling loops (e.g. a
+// curl pod retried via kubectl) with an in-process retryable client.
+type RetryHTTPClient struct {
+	Client *http.Client
+	Opts   RetryOptions
+}
+
+// NewRetryHTTPClient builds a RetryHTTPClient. client defaults to
+// http.DefaultClient when nil; opts.RetryIf is always overridden with
+// IsTransientHTTPError.
+func NewRetryHTTPClient(client *http.Client, opts RetryOptions) *RetryHTTPClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	opts.RetryIf = IsTransientHTTPError
+	return &RetryHTTPClient{Client: client, Opts: opts}
+}
+
+// Do executes req, retrying transient failures per c.Opts until ctx is done.
+func (c *RetryHTTPClient) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	err := Retry(ctx, c.Opts, func(ctx context.Context) error {
+		attemptReq := req.Clone(ctx)
+
+		r, err := c.Client.Do(attemptReq)
+		if err != nil {
+			return err
+		}
+		if isTransientStatus(r.StatusCode) {
+			_ = r.Body.Close()
+			return fmt.Errorf("transient http status %d from %s", r.StatusCode, attemptReq.URL)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// IsTransientHTTPError reports whether err looks like a transient failure
+// worth retrying: a network timeout, a connection reset, or a transient
+// status surfaced by RetryHTTPClient.Do.
+func IsTransientHTTPError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "transient http status")
+}
+
+func isTransientStatus(code int) bool {
+	return code >= 500 || code == http.StatusTooManyRequests
+}
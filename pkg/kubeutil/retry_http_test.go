@@ -0,0 +1,83 @@
+package kubeutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryHTTPClientRetriesTransientStatus(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewRetryHTTPClient(srv.Client(), RetryOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+	})
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+
+	resp, err := client.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestIsTransientHTTPError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection reset", errConnectionReset{}, true},
+		{"other error", errOther{}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsTransientHTTPError(tc.err); got != tc.want {
+				t.Errorf("IsTransientHTTPError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsTransientStatus(t *testing.T) {
+	if !isTransientStatus(http.StatusTooManyRequests) {
+		t.Error("429 should be transient")
+	}
+	if !isTransientStatus(http.StatusBadGateway) {
+		t.Error("502 should be transient")
+	}
+	if isTransientStatus(http.StatusNotFound) {
+		t.Error("404 should not be transient")
+	}
+}
+
+type errConnectionReset struct{}
+
+func (errConnectionReset) Error() string { return "read tcp: connection reset by peer" }
+
+type errOther struct{}
+
+func (errOther) Error() string { return "boom" }
@@ -0,0 +1,107 @@
+package kubeutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetrySucceedsAfterAttempts(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), RetryOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+	}, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not ready yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryStopsAtAttemptsLimit(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), RetryOptions{
+		Attempts:        2,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+	}, func(ctx context.Context) error {
+		attempts++
+		return errors.New("still not ready")
+	})
+	if err == nil {
+		t.Fatal("expected an error once Attempts is exhausted")
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestRetryStopsOnTerminalError(t *testing.T) {
+	terminal := errors.New("terminal")
+	attempts := 0
+	err := Retry(context.Background(), RetryOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		RetryIf:         func(error) bool { return false },
+	}, func(ctx context.Context) error {
+		attempts++
+		return terminal
+	})
+	if !errors.Is(err, terminal) {
+		t.Fatalf("err = %v, want %v", err, terminal)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (RetryIf should stop immediately)", attempts)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Retry(ctx, RetryOptions{InitialInterval: time.Millisecond}, func(ctx context.Context) error {
+		return errors.New("unreachable apiserver")
+	})
+	if err == nil {
+		t.Fatal("expected an error once ctx is already done")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want it to wrap context.Canceled", err)
+	}
+}
+
+func TestBackoffDelayCapsAtMaxInterval(t *testing.T) {
+	opts := RetryOptions{
+		InitialInterval: time.Second,
+		MaxInterval:     2 * time.Second,
+		Multiplier:      2.0,
+	}.withDefaults()
+
+	if d := backoffDelay(opts, 5); d != 2*time.Second {
+		t.Errorf("backoffDelay(attempt=5) = %v, want capped at %v", d, 2*time.Second)
+	}
+}
+
+func TestBackoffDelayGrowsExponentially(t *testing.T) {
+	opts := RetryOptions{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Hour,
+		Multiplier:      2.0,
+	}.withDefaults()
+
+	if d := backoffDelay(opts, 0); d != time.Second {
+		t.Errorf("backoffDelay(attempt=0) = %v, want %v", d, time.Second)
+	}
+	if d := backoffDelay(opts, 2); d != 4*time.Second {
+		t.Errorf("backoffDelay(attempt=2) = %v, want %v", d, 4*time.Second)
+	}
+}
@@ -7,20 +7,55 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/yeongki/my-operator/pkg/slo"
 )
 
-// CmdRunner abstracts command execution (stdout-only on success).
+// RunResult is the structured outcome of a CmdRunner.Run call. On success and
+// on failure alike, Stdout/Stderr are kept separate so callers don't have to
+// scrape one out of an error string.
+type RunResult struct {
+	Stdout    string
+	Stderr    string
+	ExitCode  int
+	StartedAt time.Time
+	Duration  time.Duration
+	Args      []string
+}
+
+// ExitError is returned by DefaultRunner.Run when the command exited non-zero
+// or could not be started at all (ExitCode is then -1). Callers that only
+// care about success can treat any non-nil error as failure; callers that
+// need the captured stderr/exit code can extract it with errors.As and a
+// *kubeutil.ExitError target.
+type ExitError struct {
+	Args     []string
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e *ExitError) Error() string {
+	command := strings.Join(e.Args, " ")
+	stderr := strings.TrimSpace(e.Stderr)
+	if stderr == "" {
+		return fmt.Sprintf("%q failed (exit %d): %v", command, e.ExitCode, e.Err)
+	}
+	return fmt.Sprintf("%q failed (exit %d): %s", command, e.ExitCode, stderr)
+}
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// CmdRunner abstracts command execution.
 type CmdRunner interface {
-	Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd) (string, error)
+	Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd) (RunResult, error)
 }
 
-// DefaultRunner executes commands and returns stdout.
-// On error, includes stderr+stdout in the returned error.
+// DefaultRunner executes commands on the local host.
 type DefaultRunner struct{}
 
-func (DefaultRunner) Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd) (string, error) {
+func (DefaultRunner) Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd) (RunResult, error) {
 	logger = slo.NewLogger(logger)
 
 	// Ensure ctx cancellation works even if the caller constructed cmd without context.
@@ -45,6 +80,13 @@ func (DefaultRunner) Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd)
 		c2.Env = append(c2.Env, "GO111MODULE=on")
 	}
 
+	return runAndCapture(c2, logger)
+}
+
+// runAndCapture runs c2 (already wired with ctx) and builds a RunResult for
+// DefaultRunner; the KindRunner/SSHRunner wrappers below reach it through
+// their Inner runner when that is a DefaultRunner.
+func runAndCapture(c2 *exec.Cmd, logger slo.Logger) (RunResult, error) {
 	command := strings.Join(c2.Args, " ")
 	logger.Logf("running: %q", command)
 
@@ -52,13 +94,98 @@ func (DefaultRunner) Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd)
 	c2.Stdout = &stdout
 	c2.Stderr = &stderr
 
+	startedAt := time.Now()
 	err := c2.Run()
-	outStr := stdout.String()
-	errStr := stderr.String()
+	duration := time.Since(startedAt)
+
+	result := RunResult{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		StartedAt: startedAt,
+		Duration:  duration,
+		Args:      c2.Args,
+	}
+
+	if err == nil {
+		return result, nil
+	}
+
+	exitCode := -1
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+	}
+	result.ExitCode = exitCode
+
+	return result, &ExitError{
+		Args:     c2.Args,
+		ExitCode: exitCode,
+		Stderr:   result.Stderr,
+		Err:      err,
+	}
+}
 
-	if err != nil {
-		combined := strings.TrimSpace(errStr + "\n" + outStr)
-		return outStr, fmt.Errorf("%q failed: %s: %w", command, combined, err)
+// KindRunner wraps another CmdRunner (DefaultRunner by default) and rewrites
+// every command to run inside a kind node via `docker exec <node> <args...>`.
+// Useful for commands (e.g. crictl, journalctl) that only make sense from
+// inside the node rather than the host running the test.
+type KindRunner struct {
+	// Node is the kind node container name, e.g. "kind-control-plane".
+	Node string
+	// Inner is the runner used to invoke `docker exec`. Defaults to DefaultRunner.
+	Inner CmdRunner
+}
+
+func (k KindRunner) Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd) (RunResult, error) {
+	inner := k.Inner
+	if inner == nil {
+		inner = DefaultRunner{}
 	}
-	return outStr, nil
+
+	args := append([]string{"exec", k.Node}, cmd.Args...)
+	wrapped := exec.Command("docker", args...)
+	wrapped.Dir = cmd.Dir
+	wrapped.Stdin = cmd.Stdin
+	wrapped.Env = cmd.Env
+
+	return inner.Run(ctx, logger, wrapped)
+}
+
+// SSHRunner wraps another CmdRunner (DefaultRunner by default) and rewrites
+// every command to run on a remote host via `ssh [-i KeyPath] [User@]Host -- <args...>`.
+// Useful for e2e suites targeting a remote (non-kind) cluster reachable only
+// through a bastion.
+type SSHRunner struct {
+	Host string
+	User string
+	// KeyPath is an optional path to an SSH private key; when empty, the
+	// ssh client's default key discovery is used.
+	KeyPath string
+	// Inner is the runner used to invoke `ssh`. Defaults to DefaultRunner.
+	Inner CmdRunner
+}
+
+func (s SSHRunner) Run(ctx context.Context, logger slo.Logger, cmd *exec.Cmd) (RunResult, error) {
+	inner := s.Inner
+	if inner == nil {
+		inner = DefaultRunner{}
+	}
+
+	sshArgs := []string{}
+	if s.KeyPath != "" {
+		sshArgs = append(sshArgs, "-i", s.KeyPath)
+	}
+
+	target := s.Host
+	if s.User != "" {
+		target = fmt.Sprintf("%s@%s", s.User, s.Host)
+	}
+	sshArgs = append(sshArgs, target, "--")
+	sshArgs = append(sshArgs, cmd.Args...)
+
+	wrapped := exec.Command("ssh", sshArgs...)
+	wrapped.Dir = cmd.Dir
+	wrapped.Stdin = cmd.Stdin
+	wrapped.Env = cmd.Env
+
+	return inner.Run(ctx, logger, wrapped)
 }
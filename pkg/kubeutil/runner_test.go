@@ -0,0 +1,62 @@
+package kubeutil
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestKindRunnerWrapsInDockerExec(t *testing.T) {
+	inner := &fakeRunner{}
+	r := KindRunner{Node: "kind-control-plane", Inner: inner}
+
+	if _, err := r.Run(context.Background(), nil, exec.Command("kubectl", "get", "pods")); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(inner.calls) != 1 {
+		t.Fatalf("calls = %v, want exactly one docker exec invocation", inner.calls)
+	}
+	got := strings.Join(inner.calls[0], " ")
+	if got != "docker exec kind-control-plane kubectl get pods" {
+		t.Errorf("command = %q, want %q", got, "docker exec kind-control-plane kubectl get pods")
+	}
+}
+
+func TestKindRunnerDefaultsInnerToDefaultRunner(t *testing.T) {
+	r := KindRunner{Node: "kind-control-plane"}
+	if _, err := r.Run(context.Background(), nil, exec.Command("true")); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+func TestSSHRunnerWrapsInSSH(t *testing.T) {
+	inner := &fakeRunner{}
+	r := SSHRunner{Host: "bastion.example.com", User: "ops", KeyPath: "/keys/id_rsa", Inner: inner}
+
+	if _, err := r.Run(context.Background(), nil, exec.Command("kubectl", "get", "pods")); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(inner.calls) != 1 {
+		t.Fatalf("calls = %v, want exactly one ssh invocation", inner.calls)
+	}
+	got := strings.Join(inner.calls[0], " ")
+	want := "ssh -i /keys/id_rsa ops@bastion.example.com -- kubectl get pods"
+	if got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
+
+func TestSSHRunnerWithoutUser(t *testing.T) {
+	inner := &fakeRunner{}
+	r := SSHRunner{Host: "bastion.example.com", Inner: inner}
+
+	if _, err := r.Run(context.Background(), nil, exec.Command("kubectl", "get", "pods")); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	got := strings.Join(inner.calls[0], " ")
+	want := "ssh bastion.example.com -- kubectl get pods"
+	if got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
@@ -1,89 +1,87 @@
 package kubeutil
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
-	"strings"
 	"time"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/yeongki/my-operator/pkg/slo"
 )
 
-type tokenRequest struct {
-	Status struct {
-		Token string `json:"token"`
-	} `json:"status"`
+// serviceAccountTokenRetryOpts is shared by ServiceAccountToken and mirrors
+// the old hand-rolled 2s ticker, but with jitter so many concurrent callers
+// don't all hammer the API server on the same tick.
+var serviceAccountTokenRetryOpts = RetryOptions{
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     2 * time.Second,
+	Jitter:          0.2,
 }
 
-const tokenRequestBody = `{"apiVersion":"authentication.k8s.io/v1","kind":"TokenRequest"}`
-
-// TODO(kubeutil): When we add TokenRequest options (audiences/expirationSeconds/etc),
-// stop using a raw JSON string and marshal a struct instead.
-// Rationale: easier to extend safely (optional fields), avoids fragile string edits,
-// and produces correct JSON consistently.
-
-// ServiceAccountToken requests a token for the given ServiceAccount.
+// ServiceAccountToken requests a token for the given ServiceAccount via
+// `kubectl create --raw .../token`, marshaling a authenticationv1.TokenRequest
+// so optional fields (audiences, expiration, bound object) are omitted
+// correctly instead of hand-edited into a JSON string.
 // - Retries until ctx is done.
 // - logger may be nil (no-op).
-func ServiceAccountToken(ctx context.Context, logger slo.Logger, r CmdRunner, ns, sa string) (string, error) {
+func ServiceAccountToken(ctx context.Context, logger slo.Logger, r CmdRunner, ns, sa string, opts TokenRequestOptions) (TokenResult, error) {
 	logger = slo.NewLogger(logger)
 	if r == nil {
 		r = DefaultRunner{}
 	}
 
-	if err := ctx.Err(); err != nil {
-		return "", err
+	body, err := json.Marshal(&authenticationv1.TokenRequest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "authentication.k8s.io/v1",
+			Kind:       "TokenRequest",
+		},
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         opts.Audiences,
+			ExpirationSeconds: opts.ExpirationSeconds,
+			BoundObjectRef:    toBoundObjectRef(opts.BoundObjectRef),
+		},
+	})
+	if err != nil {
+		return TokenResult{}, fmt.Errorf("marshal token request: %w", err)
 	}
 
-	var lastErr error
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	tryOnce := func() (string, error) {
+	var result TokenResult
+	err = Retry(ctx, serviceAccountTokenRetryOpts, func(ctx context.Context) error {
 		cmd := exec.Command("kubectl", "create", "--raw",
 			fmt.Sprintf("/api/v1/namespaces/%s/serviceaccounts/%s/token", ns, sa),
 			"-f", "-",
 		)
-		cmd.Stdin = strings.NewReader(tokenRequestBody)
+		cmd.Stdin = bytes.NewReader(body)
 
-		stdout, err := r.Run(ctx, logger, cmd) // ✅ ctx 반영
+		out, err := r.Run(ctx, logger, cmd)
 		if err != nil {
-			return "", fmt.Errorf("token request failed (ns=%s sa=%s): %w", ns, sa, err)
+			logger.Logf("token not ready yet: %v", err)
+			return fmt.Errorf("token request failed (ns=%s sa=%s): %w", ns, sa, err)
 		}
 
-		var tr tokenRequest
-		if err := json.Unmarshal([]byte(stdout), &tr); err != nil {
-			return "", fmt.Errorf("token response json parse failed: %w (body=%q)", err, stdout)
+		var tr authenticationv1.TokenRequest
+		if err := json.Unmarshal([]byte(out.Stdout), &tr); err != nil {
+			logger.Logf("token not ready yet: %v", err)
+			return fmt.Errorf("token response json parse failed: %w (body=%q)", err, out.Stdout)
 		}
 		if tr.Status.Token == "" {
-			return "", fmt.Errorf("token is empty")
+			logger.Logf("token not ready yet: token is empty")
+			return fmt.Errorf("token is empty")
 		}
-		return tr.Status.Token, nil
-	}
 
-	if tok, err := tryOnce(); err == nil {
-		return tok, nil
-	} else {
-		lastErr = err
-		logger.Logf("token not ready yet: %v", err)
-	}
-
-	for {
-		select {
-		case <-ctx.Done():
-			if lastErr == nil {
-				lastErr = ctx.Err()
-			}
-			return "", lastErr
-		case <-ticker.C:
-			tok, err := tryOnce()
-			if err == nil {
-				return tok, nil
-			}
-			lastErr = err
-			logger.Logf("token not ready yet: %v", err)
+		result = TokenResult{
+			Token:               tr.Status.Token,
+			ExpirationTimestamp: tr.Status.ExpirationTimestamp.Time,
 		}
+		return nil
+	})
+	if err != nil {
+		return TokenResult{}, err
 	}
+	return result, nil
 }
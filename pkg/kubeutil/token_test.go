@@ -0,0 +1,86 @@
+package kubeutil
+
+import (
+	"context"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// newFakeClientsetWithToken stubs the CreateToken subresource, which the
+// fake clientset's ObjectTracker doesn't serve on its own: it echoes back
+// whatever TokenRequestSpec the caller submitted, with Status.Token set, so
+// tests can assert on what RequestServiceAccountToken actually sent.
+func newFakeClientsetWithToken(token string, seen *authenticationv1.TokenRequestSpec) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		createAction, ok := action.(clienttesting.CreateAction)
+		if !ok || createAction.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		tr, ok := createAction.GetObject().(*authenticationv1.TokenRequest)
+		if !ok {
+			return false, nil, nil
+		}
+		if seen != nil {
+			*seen = tr.Spec
+		}
+		out := tr.DeepCopy()
+		out.Status.Token = token
+		return true, out, nil
+	})
+	return clientset
+}
+
+func TestRestClientRequestServiceAccountToken(t *testing.T) {
+	var seen authenticationv1.TokenRequestSpec
+	clientset := newFakeClientsetWithToken("t0ken", &seen)
+	c := NewRestClient(clientset, nil)
+
+	result, err := c.RequestServiceAccountToken(context.Background(), "my-operator-system", "my-operator-controller-manager", TokenRequestOptions{
+		Audiences: []string{"my-operator-metrics"},
+		BoundObjectRef: &BoundObjectReference{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Name:       "metrics-pod",
+			UID:        "abc-123",
+		},
+	})
+	if err != nil {
+		t.Fatalf("RequestServiceAccountToken: %v", err)
+	}
+	if result.Token != "t0ken" {
+		t.Errorf("Token = %q, want %q", result.Token, "t0ken")
+	}
+
+	if len(seen.Audiences) != 1 || seen.Audiences[0] != "my-operator-metrics" {
+		t.Errorf("Audiences = %v, want [my-operator-metrics]", seen.Audiences)
+	}
+	if seen.BoundObjectRef == nil || seen.BoundObjectRef.Name != "metrics-pod" || seen.BoundObjectRef.UID != "abc-123" {
+		t.Errorf("BoundObjectRef = %+v, want a ref to metrics-pod/abc-123", seen.BoundObjectRef)
+	}
+}
+
+func TestRestClientRequestServiceAccountTokenEmptyToken(t *testing.T) {
+	clientset := newFakeClientsetWithToken("", nil)
+	c := NewRestClient(clientset, nil)
+
+	_, err := c.RequestServiceAccountToken(context.Background(), "my-operator-system", "my-operator-controller-manager", TokenRequestOptions{})
+	if err == nil {
+		t.Fatal("expected an error when the apiserver returns an empty token")
+	}
+}
+
+func TestToBoundObjectRef(t *testing.T) {
+	if got := toBoundObjectRef(nil); got != nil {
+		t.Fatalf("toBoundObjectRef(nil) = %+v, want nil", got)
+	}
+
+	ref := toBoundObjectRef(&BoundObjectReference{Kind: "Pod", APIVersion: "v1", Name: "metrics-pod", UID: "abc-123"})
+	if ref == nil || ref.Kind != "Pod" || ref.Name != "metrics-pod" || string(ref.UID) != "abc-123" {
+		t.Errorf("toBoundObjectRef = %+v, want a Pod ref to metrics-pod/abc-123", ref)
+	}
+}
@@ -0,0 +1,43 @@
+package prereqs
+
+import (
+	"fmt"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+const defaultCertManagerVersion = "v1.16.3"
+
+var certManagerCRDs = []string{
+	"certificates.cert-manager.io",
+	"issuers.cert-manager.io",
+	"clusterissuers.cert-manager.io",
+	"certificaterequests.cert-manager.io",
+	"orders.acme.cert-manager.io",
+	"challenges.acme.cert-manager.io",
+}
+
+// NewCertManager builds the Component that installs cert-manager from its
+// upstream release manifest. apiExtensions may be nil, in which case
+// IsInstalled always reports false, forcing an install attempt (itself
+// idempotent).
+func NewCertManager(logger slo.Logger, runner kubeutil.CmdRunner, apiExtensions apiextensionsclientset.Interface) *ReleaseComponent {
+	return &ReleaseComponent{
+		ComponentName:  "cert-manager",
+		EnvVar:         "CERT_MANAGER_VERSION",
+		DefaultVersion: defaultCertManagerVersion,
+		ManifestURLFunc: func(version string) string {
+			return fmt.Sprintf("https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml", version)
+		},
+		ServerSideApply: true,
+		CRDs:            certManagerCRDs,
+		APIExtensions:   apiExtensions,
+		WaitTarget:      "deployment.apps/cert-manager-webhook",
+		WaitNamespace:   "cert-manager",
+		Runner:          runner,
+		Logger:          logger,
+	}
+}
@@ -0,0 +1,27 @@
+// Package prereqs installs and tears down the third-party operators e2e
+// suites depend on (cert-manager, Prometheus Operator, ...), replacing
+// one-off Install<Thing>/Uninstall<Thing> helpers with a single interface
+// suites can compose and reuse.
+package prereqs
+
+import "context"
+
+// Component is a single installable prerequisite for an e2e suite: a cert
+// authority, a metrics stack, a messaging bundle, etc. Implementations must
+// make Install/Uninstall idempotent so Suite.Ensure can be called
+// unconditionally at the start of a run.
+type Component interface {
+	// Name identifies the component for logs/error messages, e.g. "cert-manager".
+	Name() string
+	// Install applies the component's manifests. Must be idempotent.
+	Install(ctx context.Context) error
+	// Uninstall removes the component's manifests. Must be safe to call even
+	// when the component was never installed.
+	Uninstall(ctx context.Context) error
+	// IsInstalled reports whether the component is already present on the cluster.
+	IsInstalled(ctx context.Context) bool
+	// WaitReady blocks until the component is ready to serve traffic.
+	WaitReady(ctx context.Context) error
+	// Version is the pinned version string this component will install.
+	Version() string
+}
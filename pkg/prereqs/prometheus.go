@@ -0,0 +1,40 @@
+package prereqs
+
+import (
+	"fmt"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+const defaultPrometheusOperatorVersion = "v0.79.2"
+
+var prometheusOperatorCRDs = []string{
+	"prometheuses.monitoring.coreos.com",
+	"servicemonitors.monitoring.coreos.com",
+	"podmonitors.monitoring.coreos.com",
+	"alertmanagers.monitoring.coreos.com",
+}
+
+// NewPrometheusOperator builds the Component that installs the Prometheus
+// Operator bundle, so suites can assert on ServiceMonitor/PodMonitor
+// scraping without a full kube-prometheus stack.
+func NewPrometheusOperator(logger slo.Logger, runner kubeutil.CmdRunner, apiExtensions apiextensionsclientset.Interface) *ReleaseComponent {
+	return &ReleaseComponent{
+		ComponentName:  "prometheus-operator",
+		EnvVar:         "PROMETHEUS_OPERATOR_VERSION",
+		DefaultVersion: defaultPrometheusOperatorVersion,
+		ManifestURLFunc: func(version string) string {
+			return fmt.Sprintf("https://github.com/prometheus-operator/prometheus-operator/releases/download/%s/bundle.yaml", version)
+		},
+		ServerSideApply: true,
+		CRDs:            prometheusOperatorCRDs,
+		APIExtensions:   apiExtensions,
+		WaitTarget:      "deployment.apps/prometheus-operator",
+		WaitNamespace:   "default",
+		Runner:          runner,
+		Logger:          logger,
+	}
+}
@@ -0,0 +1,84 @@
+package prereqs
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// fakeRunner records the args of every command it's asked to run, so
+// ReleaseComponent tests can assert on the kubectl invocations it builds
+// without shelling out.
+type fakeRunner struct {
+	calls [][]string
+	err   error
+}
+
+func (r *fakeRunner) Run(_ context.Context, _ slo.Logger, cmd *exec.Cmd) (kubeutil.RunResult, error) {
+	r.calls = append(r.calls, cmd.Args)
+	return kubeutil.RunResult{Args: cmd.Args}, r.err
+}
+
+func TestReleaseComponentVersionPrecedence(t *testing.T) {
+	c := &ReleaseComponent{EnvVar: "TEST_RELEASE_COMPONENT_VERSION", DefaultVersion: "v1.0.0"}
+	if got := c.Version(); got != "v1.0.0" {
+		t.Fatalf("Version() = %q, want default %q", got, "v1.0.0")
+	}
+
+	t.Setenv("TEST_RELEASE_COMPONENT_VERSION", "v2.0.0")
+	if got := c.Version(); got != "v2.0.0" {
+		t.Fatalf("Version() = %q, want env override %q", got, "v2.0.0")
+	}
+
+	c.PinnedVersion = "v3.0.0"
+	if got := c.Version(); got != "v3.0.0" {
+		t.Fatalf("Version() = %q, want pinned override %q", got, "v3.0.0")
+	}
+}
+
+func TestReleaseComponentInstallServerSideApply(t *testing.T) {
+	runner := &fakeRunner{}
+	c := &ReleaseComponent{
+		ComponentName:   "cert-manager",
+		DefaultVersion:  "v1.16.3",
+		ManifestURLFunc: func(version string) string { return "https://example.invalid/" + version + ".yaml" },
+		ServerSideApply: true,
+		Runner:          runner,
+	}
+
+	if err := c.Install(context.Background()); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if len(runner.calls) != 1 {
+		t.Fatalf("calls = %v, want exactly one kubectl invocation", runner.calls)
+	}
+	args := runner.calls[0]
+	if args[1] != "apply" || args[2] != "--server-side" {
+		t.Errorf("args = %v, want a --server-side apply", args)
+	}
+}
+
+func TestReleaseComponentIsInstalledViaKubectlCheck(t *testing.T) {
+	runner := &fakeRunner{}
+	c := &ReleaseComponent{KubectlCheck: []string{"get", "deployment.apps/strimzi-cluster-operator", "-n", "kafka"}, Runner: runner}
+
+	if !c.IsInstalled(context.Background()) {
+		t.Error("IsInstalled() = false, want true when the kubectl check succeeds")
+	}
+
+	runner.err = os.ErrNotExist
+	if c.IsInstalled(context.Background()) {
+		t.Error("IsInstalled() = true, want false when the kubectl check fails")
+	}
+}
+
+func TestReleaseComponentIsInstalledNoCheckConfigured(t *testing.T) {
+	c := &ReleaseComponent{}
+	if c.IsInstalled(context.Background()) {
+		t.Error("IsInstalled() = true, want false when neither CRDs nor KubectlCheck is set")
+	}
+}
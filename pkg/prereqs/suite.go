@@ -0,0 +1,44 @@
+package prereqs
+
+import (
+	"context"
+	"fmt"
+)
+
+// Suite is an ordered set of Components an e2e run depends on.
+type Suite struct {
+	Components []Component
+}
+
+// Ensure installs every component that isn't already present, then waits for
+// all of them (including ones that were already installed) to become ready.
+// BeforeAll blocks should call this once instead of wiring up each
+// prerequisite's Install/Wait calls by hand.
+func (s Suite) Ensure(ctx context.Context) error {
+	for _, c := range s.Components {
+		if c.IsInstalled(ctx) {
+			continue
+		}
+		if err := c.Install(ctx); err != nil {
+			return fmt.Errorf("install %s: %w", c.Name(), err)
+		}
+	}
+	for _, c := range s.Components {
+		if err := c.WaitReady(ctx); err != nil {
+			return fmt.Errorf("wait ready %s: %w", c.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Teardown uninstalls every component, best-effort, returning the first error
+// encountered while still attempting the rest.
+func (s Suite) Teardown(ctx context.Context) error {
+	var firstErr error
+	for _, c := range s.Components {
+		if err := c.Uninstall(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("uninstall %s: %w", c.Name(), err)
+		}
+	}
+	return firstErr
+}
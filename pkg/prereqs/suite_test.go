@@ -0,0 +1,88 @@
+package prereqs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeComponent is an in-memory Component so Suite's orchestration can be
+// tested without shelling out to kubectl.
+type fakeComponent struct {
+	name         string
+	alreadyReady bool
+	installErr   error
+	waitErr      error
+	uninstallErr error
+
+	installCalled bool
+	waitedReady   bool
+	uninstalled   bool
+}
+
+func (f *fakeComponent) Name() string    { return f.name }
+func (f *fakeComponent) Version() string { return "v0.0.0" }
+
+func (f *fakeComponent) IsInstalled(ctx context.Context) bool { return f.alreadyReady }
+
+func (f *fakeComponent) Install(ctx context.Context) error {
+	f.installCalled = true
+	return f.installErr
+}
+
+func (f *fakeComponent) WaitReady(ctx context.Context) error {
+	f.waitedReady = true
+	return f.waitErr
+}
+
+func (f *fakeComponent) Uninstall(ctx context.Context) error {
+	f.uninstalled = true
+	return f.uninstallErr
+}
+
+func TestSuiteEnsureSkipsAlreadyInstalled(t *testing.T) {
+	already := &fakeComponent{name: "cert-manager", alreadyReady: true}
+	missing := &fakeComponent{name: "prometheus-operator"}
+
+	suite := Suite{Components: []Component{already, missing}}
+	if err := suite.Ensure(context.Background()); err != nil {
+		t.Fatalf("Ensure: %v", err)
+	}
+
+	if already.installCalled {
+		t.Error("already-installed component should not have Install called")
+	}
+	if !missing.installCalled {
+		t.Error("missing component should have Install called")
+	}
+	if !already.waitedReady || !missing.waitedReady {
+		t.Error("WaitReady should be called for every component, installed or not")
+	}
+}
+
+func TestSuiteEnsureWrapsInstallErrorWithName(t *testing.T) {
+	boom := errors.New("boom")
+	suite := Suite{Components: []Component{&fakeComponent{name: "cert-manager", installErr: boom}}}
+
+	err := suite.Ensure(context.Background())
+	if err == nil || !errors.Is(err, boom) {
+		t.Fatalf("Ensure err = %v, want it to wrap %v", err, boom)
+	}
+	if got := err.Error(); got != "install cert-manager: boom" {
+		t.Errorf("Ensure err = %q, want it to name the component, not its version", got)
+	}
+}
+
+func TestSuiteTeardownContinuesAfterError(t *testing.T) {
+	boom := errors.New("boom")
+	a := &fakeComponent{name: "cert-manager", uninstallErr: boom}
+	b := &fakeComponent{name: "prometheus-operator"}
+
+	err := Suite{Components: []Component{a, b}}.Teardown(context.Background())
+	if err == nil || !errors.Is(err, boom) {
+		t.Fatalf("Teardown err = %v, want it to wrap %v", err, boom)
+	}
+	if !b.uninstalled {
+		t.Error("Teardown should keep uninstalling remaining components after one fails")
+	}
+}
@@ -0,0 +1,37 @@
+package prereqs
+
+import (
+	"fmt"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+const (
+	defaultZookeeperKafkaVersion = "v0.36.0"
+	defaultZookeeperKafkaNS      = "kafka"
+)
+
+// NewZookeeperKafka builds the Component that installs a minimal
+// Zookeeper+Kafka bundle, mirroring the kind of dependency koperator-style
+// e2e suites need. Unlike cert-manager and the Prometheus Operator it has no
+// CRDs of its own to probe, so IsInstalled checks for the
+// strimzi-cluster-operator Deployment via kubectl instead of client-go. Only opt into this component for suites
+// that actually exercise a Kafka-backed flow; it's skipped by default.
+func NewZookeeperKafka(logger slo.Logger, runner kubeutil.CmdRunner) *ReleaseComponent {
+	return &ReleaseComponent{
+		ComponentName:  "zookeeper-kafka",
+		EnvVar:         "ZOOKEEPER_KAFKA_VERSION",
+		DefaultVersion: defaultZookeeperKafkaVersion,
+		ManifestURLFunc: func(version string) string {
+			return fmt.Sprintf("https://strimzi.io/install/%s?namespace=%s", version, defaultZookeeperKafkaNS)
+		},
+		Namespace:       defaultZookeeperKafkaNS,
+		CreateNamespace: true,
+		KubectlCheck:    []string{"get", "deployment.apps/strimzi-cluster-operator", "-n", defaultZookeeperKafkaNS},
+		WaitTarget:      "deployment.apps/strimzi-cluster-operator",
+		WaitNamespace:   defaultZookeeperKafkaNS,
+		Runner:          runner,
+		Logger:          logger,
+	}
+}
@@ -3,34 +3,51 @@ package e2e
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/yeongki/my-operator/pkg/devutil"
 	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/prereqs"
 	"github.com/yeongki/my-operator/test/e2e/harness"
 	e2eenv "github.com/yeongki/my-operator/test/e2e/internal/env"
 )
 
-const namespace = "my-operator-system"
+// metricsPodPort is the container port kube-rbac-proxy serves /metrics on
+// behind the metricsServiceName Service.
+const metricsPodPort = 8443
+
 const serviceAccountName = "my-operator-controller-manager"
 const metricsServiceName = "my-operator-controller-manager-metrics-service"
 
 var _ = Describe("Manager", Ordered, func() {
 	var (
-		cfg     e2eenv.Options
-		token   string
-		rootDir string
+		cfg       e2eenv.Options
+		profile   harness.Profile
+		namespace string
+		crbName   string
+		token     string
+		rootDir   string
+		overlay   *harness.Overlay
 	)
 
 	BeforeAll(func() {
 		cfg = e2eenv.LoadOptions()
 		By(fmt.Sprintf("ArtifactsDir=%q RunID=%q Enabled=%v", cfg.ArtifactsDir, cfg.RunID, cfg.Enabled))
 
+		profile = harness.NewProfile(cfg.RunID, CurrentSpecReport().FullText())
+		namespace = profile.Namespace
+		crbName = profile.Name("metrics-reader")
+		By(fmt.Sprintf("using e2e profile namespace=%q", namespace))
+
 		var err error
 		rootDir, err = devutil.GetProjectDir()
 		Expect(err).NotTo(HaveOccurred())
@@ -40,9 +57,9 @@ var _ = Describe("Manager", Ordered, func() {
 
 		run := func(cmd *exec.Cmd, msg string) string {
 			cmd.Dir = rootDir
-			out, err := runner.Run(ctx, logger, cmd)
+			result, err := runner.Run(ctx, logger, cmd)
 			Expect(err).NotTo(HaveOccurred(), msg)
-			return out
+			return result.Stdout
 		}
 
 		By("creating manager namespace (idempotent)")
@@ -52,32 +69,52 @@ var _ = Describe("Manager", Ordered, func() {
 		run(cmd, "Failed to create namespace")
 
 		By("labeling the namespace to enforce the security policy")
-		cmd = exec.Command("kubectl", "label", "--overwrite", "ns", namespace,
-			"pod-security.kubernetes.io/enforce=baseline")
-		_, err = runner.Run(ctx, logger, cmd)
+		err = kubeutil.SetPodSecurity(ctx, logger, runner, namespace, kubeutil.PodSecuritySpec{
+			Enforce: kubeutil.PodSecurityModeSpec{Level: kubeutil.PodSecurityBaseline},
+		})
 		Expect(err).NotTo(HaveOccurred(), "Failed to label namespace with security policy")
 
-		By("installing CRDs")
+		By("installing CRDs (cluster-scoped, shared across profiles)")
 		cmd = exec.Command("make", "install")
 		run(cmd, "Failed to install CRDs")
 
-		By("deploying the controller-manager")
-		cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectImage))
+		By("ensuring cluster-wide e2e prerequisites (cluster-scoped, shared across profiles)")
+		restCfg, err := kubeutil.RestConfigFromEnv()
+		Expect(err).NotTo(HaveOccurred(), "Failed to load rest.Config")
+		apiExtClient, err := apiextensionsclientset.NewForConfig(restCfg)
+		Expect(err).NotTo(HaveOccurred(), "Failed to build apiextensions clientset")
+
+		prereqComponents := []prereqs.Component{
+			prereqs.NewCertManager(logger, runner, apiExtClient),
+			prereqs.NewPrometheusOperator(logger, runner, apiExtClient),
+		}
+		if os.Getenv("E2E_WITH_KAFKA") != "" {
+			prereqComponents = append(prereqComponents, prereqs.NewZookeeperKafka(logger, runner))
+		}
+		Expect(prereqs.Suite{Components: prereqComponents}.Ensure(ctx)).To(Succeed(), "Failed to ensure e2e prerequisites")
+
+		By("rendering a per-profile kustomize overlay (namespace + image)")
+		overlay, err = harness.RenderOverlay(ctx, logger, runner, filepath.Join(rootDir, "config", "default"), namespace, projectImage)
+		Expect(err).NotTo(HaveOccurred(), "Failed to render kustomize overlay")
+
+		By("deploying the controller-manager into the profile namespace")
+		cmd = exec.Command("kubectl", "apply", "-k", overlay.Dir)
 		run(cmd, "Failed to deploy the controller-manager")
 
 		By("ensuring metrics reader RBAC for controller-manager SA (idempotent)")
 		Expect(kubeutil.ApplyClusterRoleBinding(
 			ctx, logger, runner,
-			"my-operator-e2e-metrics-reader",
+			crbName,
 			"my-operator-metrics-reader",
 			namespace,
 			serviceAccountName,
+			profile.Labels(),
 		)).To(Succeed())
 	})
 
 	AfterAll(func() {
 		if cfg.SkipCleanup {
-			By("E2E_SKIP_CLEANUP enabled: skipping cleanup")
+			By(fmt.Sprintf("E2E_SKIP_CLEANUP enabled: skipping cleanup of profile %q", profile.Prefix))
 			return
 		}
 
@@ -87,19 +124,22 @@ var _ = Describe("Manager", Ordered, func() {
 		By("best-effort: cleaning up curl-metrics pods for metrics")
 		cleanupCurlMetricsPods(namespace)
 
+		By("clearing pod security labels (best-effort)")
+		_ = kubeutil.ClearPodSecurity(ctx, logger, runner, namespace, kubeutil.PodSecurityEnforce)
+
 		By("undeploying the controller-manager (best-effort)")
-		cmd := exec.Command("make", "undeploy")
-		cmd.Dir = rootDir
-		_, _ = runner.Run(ctx, logger, cmd)
+		if overlay != nil {
+			cmd := exec.Command("kubectl", "delete", "-k", overlay.Dir, "--ignore-not-found")
+			_, _ = runner.Run(ctx, logger, cmd)
+			_ = overlay.Close()
+		}
 
-		By("uninstalling CRDs (best-effort)")
-		cmd = exec.Command("make", "uninstall")
-		cmd.Dir = rootDir
+		By("removing profile metrics-reader ClusterRoleBinding (best-effort)")
+		cmd := exec.Command("kubectl", "delete", "clusterrolebinding", crbName, "--ignore-not-found")
 		_, _ = runner.Run(ctx, logger, cmd)
 
-		By("removing manager namespace (best-effort)")
-		cmd = exec.Command("kubectl", "delete", "ns", namespace)
-		_, _ = runner.Run(ctx, logger, cmd)
+		By("best-effort: removing profile namespace and any leaked cluster-scoped RBAC")
+		harness.Cleanup(ctx, logger, runner, profile)
 	})
 
 	BeforeEach(func() {
@@ -107,10 +147,10 @@ var _ = Describe("Manager", Ordered, func() {
 		defer cancel()
 
 		By("requesting service account token")
-		t, err := kubeutil.ServiceAccountToken(ctx, logger, runner, namespace, serviceAccountName)
+		tr, err := kubeutil.ServiceAccountToken(ctx, logger, runner, namespace, serviceAccountName, kubeutil.TokenRequestOptions{})
 		Expect(err).NotTo(HaveOccurred())
-		Expect(t).NotTo(BeEmpty())
-		token = t
+		Expect(tr.Token).NotTo(BeEmpty())
+		token = tr.Token
 
 		By("waiting controller-manager ready")
 		waitControllerManagerReady(namespace)
@@ -146,7 +186,7 @@ var _ = Describe("Manager", Ordered, func() {
 		},
 	)
 
-	It("should ensure the metrics endpoint is serving metrics", func() {
+	It("should ensure the metrics endpoint is serving metrics (curl-pod fallback)", func() {
 		By("scraping /metrics via curl pod")
 
 		podName, err := runCurlMetricsOnce(namespace, token, metricsServiceName, serviceAccountName)
@@ -169,4 +209,36 @@ var _ = Describe("Manager", Ordered, func() {
 		Expect(text).To(ContainSubstring("controller_runtime_reconcile_total"))
 		By(fmt.Sprintf("done (timeout=%s)", 2*time.Minute))
 	})
+
+	It("should expose the reconcile counter via an in-process port-forward scrape", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		restConfig, err := kubeutil.RestConfigFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("port-forwarding to the metrics pod and scraping /metrics")
+		result, err := kubeutil.PortForwardScrape(ctx, kubeutil.PortForwardScrapeConfig{
+			RestConfig:         restConfig,
+			Clientset:          clientset,
+			Namespace:          namespace,
+			ServiceName:        metricsServiceName,
+			Port:               metricsPodPort,
+			ServiceAccountName: serviceAccountName,
+			Insecure:           true,
+			RetryOpts: kubeutil.RetryOptions{
+				InitialInterval: 2 * time.Second,
+				MaxInterval:     10 * time.Second,
+				Jitter:          0.2,
+			},
+			Logger: logger,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		value, ok := result.Sample("controller_runtime_reconcile_total", nil)
+		Expect(ok).To(BeTrue(), "controller_runtime_reconcile_total not found in scrape")
+		Expect(value).To(BeNumerically(">", 0))
+	})
 })
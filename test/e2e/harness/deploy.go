@@ -0,0 +1,69 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// Overlay is a kustomize overlay rendered into a scratch directory by
+// RenderOverlay, so a Profile's namespace and image land in the deployed
+// manifests instead of whatever config/default's base kustomization.yaml
+// happens to hardcode.
+type Overlay struct {
+	// Dir is the scratch directory holding the rendered kustomization.yaml,
+	// suitable for `kubectl apply/delete -k`.
+	Dir string
+}
+
+// RenderOverlay copies baseDir (a kustomize base, e.g. config/default) into a
+// temp directory and points `kustomize edit set namespace/image` at the
+// copy, leaving baseDir untouched so multiple Profiles can render
+// concurrently from the same source tree. Call Close when done with it.
+func RenderOverlay(ctx context.Context, logger slo.Logger, runner kubeutil.CmdRunner, baseDir, namespace, image string) (*Overlay, error) {
+	logger = slo.NewLogger(logger)
+	if runner == nil {
+		runner = kubeutil.DefaultRunner{}
+	}
+
+	dir, err := os.MkdirTemp("", "my-operator-overlay-*")
+	if err != nil {
+		return nil, fmt.Errorf("create overlay dir: %w", err)
+	}
+	overlay := &Overlay{Dir: dir}
+
+	if _, err := runner.Run(ctx, logger, exec.Command("cp", "-r", baseDir+"/.", dir)); err != nil {
+		_ = overlay.Close()
+		return nil, fmt.Errorf("copy kustomize base %q: %w", baseDir, err)
+	}
+
+	nsCmd := exec.Command("kustomize", "edit", "set", "namespace", namespace)
+	nsCmd.Dir = dir
+	if _, err := runner.Run(ctx, logger, nsCmd); err != nil {
+		_ = overlay.Close()
+		return nil, fmt.Errorf("kustomize edit set namespace %q: %w", namespace, err)
+	}
+
+	if image != "" {
+		imgCmd := exec.Command("kustomize", "edit", "set", "image", "controller="+image)
+		imgCmd.Dir = dir
+		if _, err := runner.Run(ctx, logger, imgCmd); err != nil {
+			_ = overlay.Close()
+			return nil, fmt.Errorf("kustomize edit set image %q: %w", image, err)
+		}
+	}
+
+	return overlay, nil
+}
+
+// Close removes the scratch directory backing the overlay.
+func (o *Overlay) Close() error {
+	if o == nil {
+		return nil
+	}
+	return os.RemoveAll(o.Dir)
+}
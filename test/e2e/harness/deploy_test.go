@@ -0,0 +1,87 @@
+package harness
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// fakeRunner records every command it's asked to run, so RenderOverlay tests
+// can assert on the cp/kustomize invocations it builds without touching a
+// real kustomize base.
+type fakeRunner struct {
+	calls [][]string
+	err   error
+}
+
+func (r *fakeRunner) Run(_ context.Context, _ slo.Logger, cmd *exec.Cmd) (kubeutil.RunResult, error) {
+	r.calls = append(r.calls, cmd.Args)
+	return kubeutil.RunResult{Args: cmd.Args}, r.err
+}
+
+func TestRenderOverlayRunsCopyAndKustomizeEdits(t *testing.T) {
+	r := &fakeRunner{}
+
+	overlay, err := RenderOverlay(context.Background(), nil, r, "/repo/config/default", "my-operator-abc123", "example.invalid/my-operator:latest")
+	if err != nil {
+		t.Fatalf("RenderOverlay: %v", err)
+	}
+	defer overlay.Close()
+
+	if len(r.calls) != 3 {
+		t.Fatalf("calls = %v, want cp + two kustomize edits", r.calls)
+	}
+	if got := strings.Join(r.calls[0], " "); !strings.HasPrefix(got, "cp -r /repo/config/default/.") {
+		t.Errorf("calls[0] = %q, want a cp of the base dir", got)
+	}
+	if got := strings.Join(r.calls[1], " "); got != "kustomize edit set namespace my-operator-abc123" {
+		t.Errorf("calls[1] = %q, want the namespace edit", got)
+	}
+	if got := strings.Join(r.calls[2], " "); got != "kustomize edit set image controller=example.invalid/my-operator:latest" {
+		t.Errorf("calls[2] = %q, want the image edit", got)
+	}
+
+	if _, err := os.Stat(overlay.Dir); err != nil {
+		t.Errorf("overlay.Dir = %q should exist: %v", overlay.Dir, err)
+	}
+}
+
+func TestRenderOverlaySkipsImageEditWhenEmpty(t *testing.T) {
+	r := &fakeRunner{}
+
+	overlay, err := RenderOverlay(context.Background(), nil, r, "/repo/config/default", "my-operator-abc123", "")
+	if err != nil {
+		t.Fatalf("RenderOverlay: %v", err)
+	}
+	defer overlay.Close()
+
+	if len(r.calls) != 2 {
+		t.Fatalf("calls = %v, want cp + namespace edit only", r.calls)
+	}
+}
+
+func TestOverlayCloseRemovesDir(t *testing.T) {
+	overlay, err := RenderOverlay(context.Background(), nil, &fakeRunner{}, "/repo/config/default", "my-operator-abc123", "")
+	if err != nil {
+		t.Fatalf("RenderOverlay: %v", err)
+	}
+
+	if err := overlay.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(overlay.Dir); !os.IsNotExist(err) {
+		t.Errorf("overlay.Dir = %q should be removed, stat err = %v", overlay.Dir, err)
+	}
+}
+
+func TestOverlayCloseNilSafe(t *testing.T) {
+	var overlay *Overlay
+	if err := overlay.Close(); err != nil {
+		t.Errorf("Close on nil *Overlay = %v, want nil", err)
+	}
+}
@@ -0,0 +1,82 @@
+package harness
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // used only to shorten a namespace name, not for security.
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/slo"
+)
+
+// ProfileLabel is set on every cluster-scoped resource (ClusterRoleBindings,
+// etc.) a Profile's suite creates, so Cleanup can find and remove resources
+// that outlived their namespace after a failed run.
+const ProfileLabel = "my-operator.io/e2e-profile"
+
+// Profile is a unique namespace and resource-name prefix for a single e2e
+// spec, so specs no longer collide on the hardcoded "my-operator-system"
+// namespace and can run with `-ginkgo.parallel-nodes >1`.
+type Profile struct {
+	// Namespace is the per-spec namespace the controller-manager is deployed into.
+	Namespace string
+	// Prefix is a short, namespace-safe string other per-spec resource names
+	// (ClusterRoleBindings, etc.) should be built from to stay collision-free.
+	Prefix string
+	// RunID identifies the overall test run a Profile belongs to (e.g. a CI
+	// build number), so `E2E_SKIP_CLEANUP=1` reruns can target one profile
+	// without clobbering others still in flight.
+	RunID string
+}
+
+// NewProfile mints a unique Profile for runID, deriving a short hash from the
+// Ginkgo spec name and runID: the same specName/runID pair always yields the
+// same Profile, while different specs in one run get different namespaces.
+func NewProfile(runID, specName string) Profile {
+	sum := sha1.Sum([]byte(specName + "/" + runID)) //nolint:gosec
+	shortHash := hex.EncodeToString(sum[:])[:8]
+
+	prefix := fmt.Sprintf("my-operator-%s-%s", shortHash, runID)
+	return Profile{
+		Namespace: prefix,
+		Prefix:    prefix,
+		RunID:     runID,
+	}
+}
+
+// Name builds a Profile-scoped resource name, e.g. p.Name("metrics-reader").
+func (p Profile) Name(suffix string) string {
+	return fmt.Sprintf("%s-%s", p.Prefix, suffix)
+}
+
+// Labels returns the label set Profile-scoped cluster-wide resources should
+// carry so Cleanup can find them later.
+func (p Profile) Labels() map[string]string {
+	return map[string]string{ProfileLabel: p.Prefix}
+}
+
+// Cleanup best-effort deletes the profile's namespace and any leaked
+// cluster-scoped RBAC tagged with the profile label, mirroring the minikube
+// integration test's teardown pattern: never fail the test on cleanup error,
+// just log and move on.
+func Cleanup(ctx context.Context, logger slo.Logger, runner kubeutil.CmdRunner, p Profile) {
+	logger = slo.NewLogger(logger)
+	if runner == nil {
+		runner = kubeutil.DefaultRunner{}
+	}
+
+	cmd := exec.Command("kubectl", "delete", "clusterrolebinding",
+		"-l", fmt.Sprintf("%s=%s", ProfileLabel, p.Prefix),
+		"--ignore-not-found",
+	)
+	if _, err := runner.Run(ctx, logger, cmd); err != nil {
+		logger.Logf("cleanup: deleting leaked clusterrolebindings for profile %q: %v", p.Prefix, err)
+	}
+
+	cmd = exec.Command("kubectl", "delete", "ns", p.Namespace, "--ignore-not-found")
+	if _, err := runner.Run(ctx, logger, cmd); err != nil {
+		logger.Logf("cleanup: deleting namespace %q: %v", p.Namespace, err)
+	}
+}
@@ -0,0 +1,52 @@
+package harness
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewProfileIsDeterministicAndCollisionFree(t *testing.T) {
+	a := NewProfile("run-1", "Manager should do a thing")
+	b := NewProfile("run-1", "Manager should do a thing")
+	if a.Namespace != b.Namespace {
+		t.Errorf("NewProfile should be deterministic for the same specName/runID, got %q and %q", a.Namespace, b.Namespace)
+	}
+
+	c := NewProfile("run-1", "Manager should do a different thing")
+	if a.Namespace == c.Namespace {
+		t.Errorf("NewProfile(%q) and NewProfile(%q) collided on namespace %q", "Manager should do a thing", "Manager should do a different thing", a.Namespace)
+	}
+}
+
+func TestProfileName(t *testing.T) {
+	p := Profile{Prefix: "my-operator-deadbeef-run-1"}
+	if got := p.Name("metrics-reader"); got != "my-operator-deadbeef-run-1-metrics-reader" {
+		t.Errorf("Name(metrics-reader) = %q, want %q", got, "my-operator-deadbeef-run-1-metrics-reader")
+	}
+}
+
+func TestProfileLabels(t *testing.T) {
+	p := Profile{Prefix: "my-operator-deadbeef-run-1"}
+	got := p.Labels()
+	if got[ProfileLabel] != p.Prefix {
+		t.Errorf("Labels() = %v, want %q=%q", got, ProfileLabel, p.Prefix)
+	}
+}
+
+func TestCleanupDeletesLabeledCRBsAndNamespace(t *testing.T) {
+	r := &fakeRunner{}
+	p := Profile{Namespace: "my-operator-deadbeef-run-1", Prefix: "my-operator-deadbeef-run-1"}
+
+	Cleanup(context.Background(), nil, r, p)
+
+	if len(r.calls) != 2 {
+		t.Fatalf("calls = %v, want exactly two kubectl invocations", r.calls)
+	}
+	if got := strings.Join(r.calls[0], " "); !strings.Contains(got, "delete clusterrolebinding") || !strings.Contains(got, ProfileLabel+"="+p.Prefix) {
+		t.Errorf("calls[0] = %q, want a label-selector clusterrolebinding delete", got)
+	}
+	if got := strings.Join(r.calls[1], " "); !strings.Contains(got, "delete ns "+p.Namespace) {
+		t.Errorf("calls[1] = %q, want the namespace delete", got)
+	}
+}
@@ -0,0 +1,51 @@
+package e2e
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+)
+
+// waiterRetryOpts drives waitControllerManagerReady's polling with a
+// jittered backoff instead of a hand-rolled fixed-interval loop, so waiters
+// don't all hammer the API server in lockstep. waitServiceHasEndpoints uses
+// the retry options built into Client.WaitForServiceEndpoints instead.
+var waiterRetryOpts = kubeutil.RetryOptions{
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     10 * time.Second,
+	Jitter:          0.2,
+}
+
+// waitControllerManagerReady blocks until the controller-manager Deployment
+// in ns reports Available, failing the current spec if it doesn't happen
+// within 5 minutes.
+func waitControllerManagerReady(ns string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	err := kubeutil.Retry(ctx, waiterRetryOpts, func(ctx context.Context) error {
+		cmd := exec.Command("kubectl", "wait", "deployment.apps/my-operator-controller-manager",
+			"--for", "condition=Available",
+			"--namespace", ns,
+			"--timeout", "5s",
+		)
+		_, err := runner.Run(ctx, logger, cmd)
+		return err
+	})
+	Expect(err).NotTo(HaveOccurred(), "controller-manager in namespace %q did not become ready", ns)
+}
+
+// waitServiceHasEndpoints blocks until the named Service has at least one
+// ready endpoint, reusing the same Retry-backed check Client.WaitForServiceEndpoints
+// implementations share instead of hand-rolling another poll here.
+func waitServiceHasEndpoints(ns, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	err := kubeutil.NewCmdClient(runner, logger).WaitForServiceEndpoints(ctx, ns, name)
+	Expect(err).NotTo(HaveOccurred(), "service %s/%s never got ready endpoints", ns, name)
+}
@@ -19,21 +19,28 @@ package utils
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2" // nolint:revive,staticcheck
-)
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 
-const (
-	certmanagerVersion = "v1.16.3"
-	certmanagerURLTmpl = "https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml"
+	"github.com/yeongki/my-operator/pkg/kubeutil"
+	"github.com/yeongki/my-operator/pkg/prereqs"
+	"github.com/yeongki/my-operator/pkg/slo"
 )
 
-func certmanagerURL() string {
-	return fmt.Sprintf(certmanagerURLTmpl, certmanagerVersion)
+// cmdRunnerAdapter adapts this package's ginkgo-aware Run to kubeutil.CmdRunner,
+// so InstallCertManager/UninstallCertManager can reuse the prereqs subsystem
+// without e2e suites threading a second runner through GinkgoWriter.
+type cmdRunnerAdapter struct{}
+
+func (cmdRunnerAdapter) Run(_ context.Context, _ slo.Logger, cmd *exec.Cmd) (kubeutil.RunResult, error) {
+	out, err := Run(cmd)
+	return kubeutil.RunResult{Stdout: out, Args: cmd.Args}, err
 }
 
 // TODO: Refactor utils package to remove dependency on Ginkgo (GinkgoWriter).
@@ -84,63 +91,36 @@ func Run(cmd *exec.Cmd) (string, error) {
 	return outStr, nil
 }
 
-// UninstallCertManager uninstalls the cert manager
+// UninstallCertManager uninstalls the cert manager. Kept as a thin wrapper
+// around prereqs.NewCertManager for callers that aren't ready to compose a full
+// prereqs.Suite yet.
 func UninstallCertManager() error {
-	url := certmanagerURL()
-	cmd := exec.Command("kubectl", "delete", "-f", url)
-	_, err := Run(cmd)
-	return err
+	return prereqs.NewCertManager(nil, cmdRunnerAdapter{}, nil).Uninstall(context.Background())
 }
 
-// InstallCertManager installs the cert manager bundle.
+// InstallCertManager installs the cert manager bundle and waits for its
+// webhook to be ready.
 func InstallCertManager() error {
-	url := certmanagerURL()
-	cmd := exec.Command("kubectl", "apply", "-f", url)
-	if _, err := Run(cmd); err != nil {
+	cm := prereqs.NewCertManager(nil, cmdRunnerAdapter{}, nil)
+	if err := cm.Install(context.Background()); err != nil {
 		return err
 	}
-	// Wait for cert-manager-webhook to be ready, which can take time if cert-manager
-	// was re-installed after uninstalling on a cluster.
-	cmd = exec.Command("kubectl", "wait", "deployment.apps/cert-manager-webhook",
-		"--for", "condition=Available",
-		"--namespace", "cert-manager",
-		"--timeout", "5m",
-	)
-	_, err := Run(cmd)
-	return err
+	return cm.WaitReady(context.Background())
 }
 
-// IsCertManagerCRDsInstalled checks if any Cert Manager CRDs are installed
-// by verifying the existence of key CRDs related to Cert Manager.
+// IsCertManagerCRDsInstalled checks whether the cert-manager CRDs are
+// installed by querying them directly via client-go, instead of grepping
+// `kubectl get crds` output.
 func IsCertManagerCRDsInstalled() bool {
-	// List of common Cert Manager CRDs
-	certManagerCRDs := []string{
-		"certificates.cert-manager.io",
-		"issuers.cert-manager.io",
-		"clusterissuers.cert-manager.io",
-		"certificaterequests.cert-manager.io",
-		"orders.acme.cert-manager.io",
-		"challenges.acme.cert-manager.io",
-	}
-
-	// Execute the kubectl command to get all CRDs
-	cmd := exec.Command("kubectl", "get", "crds")
-	output, err := Run(cmd)
+	cfg, err := kubeutil.RestConfigFromEnv()
 	if err != nil {
 		return false
 	}
-
-	// Check if any of the Cert Manager CRDs are present
-	crdList := GetNonEmptyLines(output)
-	for _, crd := range certManagerCRDs {
-		for _, line := range crdList {
-			if strings.Contains(line, crd) {
-				return true
-			}
-		}
+	clientset, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		return false
 	}
-
-	return false
+	return prereqs.NewCertManager(nil, nil, clientset).IsInstalled(context.Background())
 }
 
 // LoadImageToKindClusterWithName loads a local docker image to the kind cluster